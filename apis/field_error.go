@@ -0,0 +1,338 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apis
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// FieldError is used to construct warnings/errors that point at one or more
+// field paths, with enough structure (Also/ViaField, nested sub-errors) to
+// be built up by independent validators and still convert cleanly into a
+// Kubernetes field.ErrorList via ConvertToFieldErrorToErrorList.
+//
+// Type and BadValue let a caller that already knows its apimachinery
+// field.ErrorType (via one of the ErrXxx constructors below) carry that
+// classification through Also/ViaField/nested merging, instead of having
+// convertToFieldError re-derive it by sniffing Message.
+type FieldError struct {
+	Message string
+	Paths   []string
+	Details string
+	// Type is the apimachinery field.ErrorType this error should convert
+	// to. It is optional: the zero value means "classify err.Message with
+	// the legacy string heuristics instead", which is how errors built by
+	// hand (e.g. Tekton's merged FieldErrors) keep working.
+	Type field.ErrorType
+	// BadValue is the value that failed validation, reported back as-is on
+	// the converted field.Error. Only meaningful when Type is set. For
+	// field.ErrorTypeTooMany it instead holds the actual quantity (int) that
+	// was over the limit, since field.TooMany has no "value" of its own.
+	BadValue interface{}
+	// Limit is the maxLength for a field.ErrorTypeTooLong error, or the
+	// maxQuantity for a field.ErrorTypeTooMany error. Only meaningful when
+	// Type is one of those two.
+	Limit int
+	// errors holds sibling errors merged in via Also, or nested child
+	// errors added via ViaField/ViaIndex/ViaKey.
+	errors []FieldError
+}
+
+// ErrMissingField returns a new FieldError for a missing required field(s).
+func ErrMissingField(fieldPaths ...string) *FieldError {
+	return &FieldError{
+		Message: "missing field(s)",
+		Paths:   fieldPaths,
+		Type:    field.ErrorTypeRequired,
+	}
+}
+
+// ErrDisallowedFields returns a new FieldError for a set of fields that
+// should not have been set.
+func ErrDisallowedFields(fieldPaths ...string) *FieldError {
+	return &FieldError{
+		Message: "must not set the field(s)",
+		Paths:   fieldPaths,
+		Type:    field.ErrorTypeForbidden,
+	}
+}
+
+// ErrInvalidValue returns a new FieldError for a field that has an
+// unsupported or invalid value.
+func ErrInvalidValue(value interface{}, fieldPaths ...string) *FieldError {
+	return &FieldError{
+		Message:  fmt.Sprintf("invalid value: %v", value),
+		Paths:    fieldPaths,
+		Type:     field.ErrorTypeInvalid,
+		BadValue: value,
+	}
+}
+
+// ErrMissingOneOf returns a new FieldError for when none of the field paths
+// in fieldPaths is set.
+func ErrMissingOneOf(fieldPaths ...string) *FieldError {
+	return &FieldError{
+		Message: "expected exactly one, got neither",
+		Paths:   fieldPaths,
+		Type:    field.ErrorTypeForbidden,
+	}
+}
+
+// ErrMultipleOneOf returns a new FieldError for when more than one of the
+// field paths in fieldPaths is set.
+func ErrMultipleOneOf(fieldPaths ...string) *FieldError {
+	return &FieldError{
+		Message: "expected exactly one, got both",
+		Paths:   fieldPaths,
+		Type:    field.ErrorTypeForbidden,
+	}
+}
+
+// ErrDisallowedUpdateDeprecatedFields returns a new FieldError for a set of
+// deprecated field paths that were updated.
+func ErrDisallowedUpdateDeprecatedFields(fieldPaths ...string) *FieldError {
+	return &FieldError{
+		Message: "must not update deprecated field(s)",
+		Paths:   fieldPaths,
+		Type:    field.ErrorTypeForbidden,
+	}
+}
+
+// ErrInvalidKeyName returns a new FieldError for a map key with an invalid
+// name, e.g. one carrying characters forbidden in annotations/labels.
+func ErrInvalidKeyName(value, fieldPath string, details ...string) *FieldError {
+	return &FieldError{
+		Message: "invalid key name " + value,
+		Paths:   []string{fieldPath},
+		Details: strings.Join(details, ", "),
+		Type:    field.ErrorTypeForbidden,
+	}
+}
+
+// ErrDuplicateValue returns a new FieldError for a value that collides with
+// another value that has already been set for fieldPaths.
+func ErrDuplicateValue(value interface{}, fieldPaths ...string) *FieldError {
+	return &FieldError{
+		Message:  fmt.Sprintf("duplicate value: %v", value),
+		Paths:    fieldPaths,
+		Type:     field.ErrorTypeDuplicate,
+		BadValue: value,
+	}
+}
+
+// ErrInvalidTypeValue returns a new FieldError for a value whose Go type
+// does not match what fieldPaths expects.
+func ErrInvalidTypeValue(value interface{}, fieldPaths ...string) *FieldError {
+	return &FieldError{
+		Message:  fmt.Sprintf("invalid type: %v", value),
+		Paths:    fieldPaths,
+		Type:     field.ErrorTypeTypeInvalid,
+		BadValue: value,
+	}
+}
+
+// ErrUnsupportedValue returns a new FieldError for a value outside the set
+// of values supported for fieldPaths.
+func ErrUnsupportedValue(value interface{}, fieldPaths ...string) *FieldError {
+	return &FieldError{
+		Message:  fmt.Sprintf("unsupported value: %v", value),
+		Paths:    fieldPaths,
+		Type:     field.ErrorTypeNotSupported,
+		BadValue: value,
+	}
+}
+
+// ErrValueNotFound returns a new FieldError for a referenced value that
+// could not be found, e.g. a dangling reference to another object.
+func ErrValueNotFound(value interface{}, fieldPaths ...string) *FieldError {
+	return &FieldError{
+		Message:  fmt.Sprintf("not found: %v", value),
+		Paths:    fieldPaths,
+		Type:     field.ErrorTypeNotFound,
+		BadValue: value,
+	}
+}
+
+// ErrValueTooLong returns a new FieldError for a value that exceeds
+// maxLength, the maximum length allowed for fieldPaths.
+func ErrValueTooLong(value interface{}, maxLength int, fieldPaths ...string) *FieldError {
+	return &FieldError{
+		Message:  fmt.Sprintf("too long: %v", value),
+		Paths:    fieldPaths,
+		Type:     field.ErrorTypeTooLong,
+		BadValue: value,
+		Limit:    maxLength,
+	}
+}
+
+// ErrTooManyValues returns a new FieldError for fieldPaths that have
+// actualQuantity entries set, more than the maxQuantity allowed.
+func ErrTooManyValues(actualQuantity, maxQuantity int, fieldPaths ...string) *FieldError {
+	return &FieldError{
+		Message:  "too many: values",
+		Paths:    fieldPaths,
+		Type:     field.ErrorTypeTooMany,
+		BadValue: actualQuantity,
+		Limit:    maxQuantity,
+	}
+}
+
+// ErrInternal wraps cause as a FieldError with Type field.ErrorTypeInternal.
+func ErrInternal(cause error, fieldPaths ...string) *FieldError {
+	return &FieldError{
+		Message: fmt.Sprintf("Internal Error: %v", cause),
+		Paths:   fieldPaths,
+		Type:    field.ErrorTypeInternal,
+	}
+}
+
+// Error implements error.
+func (fe *FieldError) Error() string {
+	if fe == nil {
+		return ""
+	}
+	if len(fe.errors) > 0 {
+		msgs := make([]string, 0, len(fe.errors))
+		for _, e := range fe.errors {
+			msgs = append(msgs, e.Error())
+		}
+		return strings.Join(msgs, "\n")
+	}
+	if len(fe.Paths) > 0 {
+		return fmt.Sprintf("%s: %s", flatten(fe.Paths), fe.Message)
+	}
+	return fe.Message
+}
+
+// clone returns a deep-enough copy of fe (safe to mutate errors/Paths on
+// without affecting the receiver), or an empty FieldError if fe is nil.
+func (fe *FieldError) clone() *FieldError {
+	if fe == nil {
+		return &FieldError{}
+	}
+	cloned := *fe
+	cloned.Paths = append([]string(nil), fe.Paths...)
+	cloned.errors = append([]FieldError(nil), fe.errors...)
+	return &cloned
+}
+
+// asErrors returns fe as a flat slice of sibling FieldErrors: its own
+// sub-errors if it has any, itself as the sole entry if it is a leaf, or
+// nil if fe is nil/empty. It is the building block Also uses to merge
+// FieldErrors without losing a leaf's own Message/Type/BadValue once it
+// becomes a sibling under a combined error.
+func asErrors(fe *FieldError) []FieldError {
+	if fe == nil {
+		return nil
+	}
+	if len(fe.errors) > 0 {
+		return fe.errors
+	}
+	if fe.Message == "" && len(fe.Paths) == 0 {
+		return nil
+	}
+	return []FieldError{*fe}
+}
+
+// Also collects errs as siblings of fe and returns the combined FieldError,
+// the way multiple independent field validations are typically combined:
+//
+//	errs := fe.Also(checkA(obj), checkB(obj))
+//
+// Type/BadValue on every merged leaf are preserved as-is.
+func (fe *FieldError) Also(errs ...*FieldError) *FieldError {
+	merged := asErrors(fe)
+	for _, err := range errs {
+		merged = append(merged, asErrors(err)...)
+	}
+	switch len(merged) {
+	case 0:
+		return nil
+	case 1:
+		single := merged[0]
+		return &single
+	default:
+		return &FieldError{errors: merged}
+	}
+}
+
+// ViaField returns a new FieldError with prefix prepended to the Paths of
+// fe (and, recursively, of every sub-error), e.g. turning a field "name"
+// error into a "spec.name" error via fe.ViaField("spec"). Type/BadValue are
+// untouched.
+func (fe *FieldError) ViaField(prefix ...string) *FieldError {
+	if fe == nil {
+		return nil
+	}
+	newErr := fe.clone()
+	if len(newErr.errors) > 0 {
+		for i := range newErr.errors {
+			newErr.errors[i] = *newErr.errors[i].ViaField(prefix...)
+		}
+		return newErr
+	}
+	newErr.Paths = append(append([]string{}, prefix...), newErr.Paths...)
+	return newErr
+}
+
+// ViaIndex is a shortcut for ViaField(fmt.Sprintf("[%d]", index)), for
+// prefixing an error with its position in an array/slice.
+func (fe *FieldError) ViaIndex(index int) *FieldError {
+	return fe.ViaField(fmt.Sprintf("[%d]", index))
+}
+
+// ViaKey is a shortcut for ViaField(fmt.Sprintf("[%s]", key)), for
+// prefixing an error with its position in a map.
+func (fe *FieldError) ViaKey(key string) *FieldError {
+	return fe.ViaField(fmt.Sprintf("[%s]", key))
+}
+
+// ViaFieldIndex is a shortcut for ViaIndex(index).ViaField(field), e.g.
+// turning a "name" error into a "tasks[1].name" error.
+func (fe *FieldError) ViaFieldIndex(field string, index int) *FieldError {
+	return fe.ViaIndex(index).ViaField(field)
+}
+
+// ViaFieldKey is a shortcut for ViaKey(key).ViaField(field), e.g. turning a
+// "name" error into a "params[my-key].name" error.
+func (fe *FieldError) ViaFieldKey(field, key string) *FieldError {
+	return fe.ViaKey(key).ViaField(field)
+}
+
+// flatten joins path name components into a single dotted name, keeping
+// "[idx]"/"[key]" selectors attached to the component they index into
+// instead of separated by a dot.
+func flatten(names []string) string {
+	var sb strings.Builder
+	for _, part := range names {
+		if part == "" {
+			continue
+		}
+		if strings.HasPrefix(part, "[") {
+			sb.WriteString(part)
+			continue
+		}
+		if sb.Len() > 0 {
+			sb.WriteByte('.')
+		}
+		sb.WriteString(part)
+	}
+	return sb.String()
+}
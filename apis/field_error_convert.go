@@ -24,39 +24,132 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
+// Level indicates how a converted sub-error should be surfaced: as a
+// blocking field.Error, or as a non-blocking warning string (e.g. via
+// admissionv1.AdmissionResponse.Warnings).
+type Level int
+
+const (
+	// LevelError surfaces the sub-error as a blocking field.Error. This is
+	// the default when no LevelFunc is supplied.
+	LevelError Level = iota
+	// LevelWarning surfaces the sub-error as a warning instead of failing
+	// the request, e.g. for deprecation notices.
+	LevelWarning
+	// LevelDryRun behaves like LevelWarning but is meant for rules that are
+	// still being rolled out and should never block, even once promoted.
+	LevelDryRun
+)
+
+// LevelFunc decides the Level at which a single FieldError leaf should be
+// surfaced. It is evaluated once per leaf FieldError (i.e. one with no
+// nested errors) produced while walking err; leaves dropped by the path
+// filter are not passed to it.
+type LevelFunc func(FieldError) Level
+
 // ConvertToFieldErrorToErrorListIgnorePathPrefix converts a FieldError into a field.ErrorList
 // and ignores the path prefix if the error path not starts with the prefix
 // and removes the prefix from the path
 func ConvertToFieldErrorToErrorListIgnorePathPrefix(ctx context.Context, err *FieldError, path, ignorePathPrefix *field.Path) (errs field.ErrorList) {
-	errs = convertToFieldErrorToErrorList(ctx, err, path, ignorePathPrefix)
+	errs, _ = ConvertToFieldErrorToResult(ctx, err, path, ignorePathPrefix, nil)
+	return
+}
+
+// ConvertToFieldErrorToErrorList converts a FieldError into a field.ErrorList
+func ConvertToFieldErrorToErrorList(ctx context.Context, err *FieldError, path *field.Path) (errs field.ErrorList) {
+	return ConvertToFieldErrorToErrorListIgnorePathPrefix(ctx, err, path, nil)
+}
+
+// ConvertToFieldErrorToErrorListWithFilter converts a FieldError into a
+// field.ErrorList, keeping, dropping and rewriting paths with filter instead
+// of a single raw path-prefix string. A nil filter keeps every path
+// unchanged, same as ConvertToFieldErrorToErrorList.
+func ConvertToFieldErrorToErrorListWithFilter(ctx context.Context, err *FieldError, path *field.Path, filter *PathFilter) (errs field.ErrorList) {
+	errs, _ = convertToFieldErrorToResultWithFilter(ctx, err, path, filter, nil)
+	errs = removeDumplicateError(errs)
+	return
+}
+
+// ConvertToFieldErrorToResult converts a FieldError into a field.ErrorList of
+// blocking errors plus a list of human readable warnings, so that a webhook
+// can surface deprecation notices or soft validations via
+// admissionv1.AdmissionResponse.Warnings without failing the request.
+//
+// levelFunc classifies each leaf FieldError; any leaf classified as
+// LevelWarning or LevelDryRun is rendered into warnings instead of errs. A
+// nil levelFunc treats every leaf as LevelError, which makes this behave
+// exactly like ConvertToFieldErrorToErrorListIgnorePathPrefix.
+//
+// ignorePathPrefix, duplicate removal and path handling behave the same as
+// ConvertToFieldErrorToErrorListIgnorePathPrefix: it is translated into a
+// PathFilter that keeps only paths under ignorePathPrefix and strips the
+// prefix off, then delegated to the same filter-aware walk as
+// ConvertToFieldErrorToErrorListWithFilter.
+func ConvertToFieldErrorToResult(ctx context.Context, err *FieldError, path, ignorePathPrefix *field.Path, levelFunc LevelFunc) (errs field.ErrorList, warnings []string) {
+	errs, warnings = convertToFieldErrorToResultWithFilter(ctx, err, path, filterForIgnorePathPrefix(ignorePathPrefix), levelFunc)
 	// Upgrading tekton to v0.56, errors may repeat
 	// In tekton, it merged list of FieldErrors, remove duplicate errors
 	// Ref: https://github.com/knative/pkg/blob/f5b42e8dea446a2a695ded0ea7c445317aed78b3/apis/field_error.go#L341-L345
 	errs = removeDumplicateError(errs)
+	warnings = removeDumplicateWarning(warnings)
 	return
 }
 
-// ConvertToFieldErrorToErrorList converts a FieldError into a field.ErrorList
-func ConvertToFieldErrorToErrorList(ctx context.Context, err *FieldError, path *field.Path) (errs field.ErrorList) {
-	return ConvertToFieldErrorToErrorListIgnorePathPrefix(ctx, err, path, nil)
+// filterForIgnorePathPrefix builds the PathFilter equivalent of the legacy
+// ignorePathPrefix behaviour: keep only errors under ignorePathPrefix, and
+// strip the prefix off the kept paths. A nil or empty ignorePathPrefix keeps
+// everything unchanged.
+func filterForIgnorePathPrefix(ignorePathPrefix *field.Path) *PathFilter {
+	if ignorePathPrefix == nil || ignorePathPrefix.String() == emptyFieldPathString {
+		return nil
+	}
+	return NewPathFilter().Include(ignorePathPrefix).Rewrite(ignorePathPrefix, field.NewPath(""))
 }
 
-// convertToFieldErrorToErrorList converts a FieldError into a field.ErrorList
-func convertToFieldErrorToErrorList(ctx context.Context, err *FieldError, path, ignorePathPrefix *field.Path) (errs field.ErrorList) {
+// convertToFieldErrorToResultWithFilter converts a FieldError into a
+// field.ErrorList and a warnings list, deferring the error/warning split for
+// each leaf to levelFunc and the path handling to filter.
+func convertToFieldErrorToResultWithFilter(ctx context.Context, err *FieldError, path *field.Path, filter *PathFilter, levelFunc LevelFunc) (errs field.ErrorList, warnings []string) {
 	if err == nil {
 		return
 	}
 	if len(err.errors) > 0 {
 		for i, oneErr := range err.errors {
+			var subErrs field.ErrorList
+			var subWarnings []string
 			if len(oneErr.errors) > 0 {
-				errs = append(errs, convertToFieldErrorToErrorList(ctx, &err.errors[i], path, ignorePathPrefix)...)
+				subErrs, subWarnings = convertToFieldErrorToResultWithFilter(ctx, &err.errors[i], path, filter, levelFunc)
 			} else {
-				errs = append(errs, convertToFieldError(ctx, oneErr, path, ignorePathPrefix)...)
+				subErrs, subWarnings = convertToFieldErrorLeafToResult(ctx, oneErr, path, filter, levelFunc)
 			}
+			errs = append(errs, subErrs...)
+			warnings = append(warnings, subWarnings...)
 		}
 		return
 	}
-	errs = append(errs, convertToFieldError(ctx, *err, path, ignorePathPrefix)...)
+	errs, warnings = convertToFieldErrorLeafToResult(ctx, *err, path, filter, levelFunc)
+	return
+}
+
+// convertToFieldErrorLeafToResult classifies a single leaf FieldError into
+// either errs or warnings, depending on levelFunc.
+func convertToFieldErrorLeafToResult(ctx context.Context, err FieldError, path *field.Path, filter *PathFilter, levelFunc LevelFunc) (errs field.ErrorList, warnings []string) {
+	fieldErrs := convertToFieldError(ctx, err, path, filter)
+	if len(fieldErrs) == 0 {
+		return
+	}
+	level := LevelError
+	if levelFunc != nil {
+		level = levelFunc(err)
+	}
+	switch level {
+	case LevelWarning, LevelDryRun:
+		for _, fieldErr := range fieldErrs {
+			warnings = append(warnings, fieldErr.Error())
+		}
+	default:
+		errs = fieldErrs
+	}
 	return
 }
 
@@ -73,9 +166,57 @@ func removeDumplicateError(errs field.ErrorList) (newErrs field.ErrorList) {
 	return
 }
 
+// removeDumplicateWarning removes duplicate warnings from the list
+func removeDumplicateWarning(warnings []string) (newWarnings []string) {
+	seen := make(map[string]bool)
+	for _, warning := range warnings {
+		if _, ok := seen[warning]; !ok {
+			seen[warning] = true
+			newWarnings = append(newWarnings, warning)
+		}
+	}
+	return
+}
+
 var emptyFieldPathString = field.NewPath("").String()
 
-func convertToFieldError(_ context.Context, err FieldError, path, ignorePathPrefix *field.Path) (errs field.ErrorList) {
+// convertTypedFieldError maps a FieldError that carries an explicit Type
+// (set by one of the ErrXxx constructors in field_error.go, or by any other
+// caller that sets it directly) to the matching field.Error constructor, so
+// the apimachinery ErrorType and BadValue survive the conversion instead of
+// being re-derived from err.Message.
+func convertTypedFieldError(fieldPath *field.Path, err FieldError) *field.Error {
+	switch err.Type {
+	case field.ErrorTypeRequired:
+		return field.Required(fieldPath, err.Message)
+	case field.ErrorTypeForbidden:
+		return field.Forbidden(fieldPath, err.Message)
+	case field.ErrorTypeDuplicate:
+		return field.Duplicate(fieldPath, err.BadValue)
+	case field.ErrorTypeTypeInvalid:
+		return field.TypeInvalid(fieldPath, err.BadValue, err.Message)
+	case field.ErrorTypeNotSupported:
+		return field.NotSupported(fieldPath, err.BadValue, nil)
+	case field.ErrorTypeNotFound:
+		return field.NotFound(fieldPath, err.BadValue)
+	case field.ErrorTypeTooLong:
+		return field.TooLong(fieldPath, err.BadValue, err.Limit)
+	case field.ErrorTypeTooMany:
+		actual, _ := err.BadValue.(int)
+		return field.TooMany(fieldPath, actual, err.Limit)
+	case field.ErrorTypeInternal:
+		return field.InternalError(fieldPath, errors.New(err.Message))
+	default:
+		return field.Invalid(fieldPath, err.BadValue, err.Message)
+	}
+}
+
+// convertToFieldError classifies a single FieldError leaf into a
+// *field.Error. FieldErrors built via the ErrXxx constructors carry their
+// own Type, which is preferred; everything else falls back to sniffing
+// err.Message the way this package always has, for legacy callers (e.g.
+// Tekton's merged FieldErrors) that construct FieldError by hand.
+func convertToFieldError(_ context.Context, err FieldError, path *field.Path, filter *PathFilter) (errs field.ErrorList) {
 	fieldPath := path
 
 	// this error is a bit special, the paths not really the path
@@ -89,17 +230,16 @@ func convertToFieldError(_ context.Context, err FieldError, path, ignorePathPref
 		err.Paths = nil
 	}
 
-	pathString := flatten(err.Paths)
-	if ignorePathPrefix != nil && ignorePathPrefix.String() != emptyFieldPathString {
-		prefix := ignorePathPrefix.String()
-		if !strings.HasPrefix(pathString, prefix) {
+	if filter != nil {
+		segments, keep := filter.apply(flatten(err.Paths))
+		if !keep {
 			return nil
 		}
-		pathString = strings.TrimPrefix(pathString, prefix)
-		if strings.HasPrefix(pathString, ".") {
-			pathString = strings.TrimPrefix(pathString, ".")
+		if len(segments) > 0 {
+			err.Paths = []string{joinSegments(segments)}
+		} else {
+			err.Paths = nil
 		}
-		err.Paths = []string{pathString}
 	}
 
 	if len(err.Paths) > 0 {
@@ -114,11 +254,30 @@ func convertToFieldError(_ context.Context, err FieldError, path, ignorePathPref
 	// checking which error
 	var fieldErr *field.Error
 	switch {
+	case err.Type != "":
+		fieldErr = convertTypedFieldError(fieldPath, err)
 	case strings.Contains(err.Message, "missing field(s)"):
 		fieldErr = field.Required(fieldPath, err.Message)
 	case strings.Contains(err.Message, "invalid value: "):
 		value := strings.TrimPrefix(err.Message, "invalid value: ")
 		fieldErr = field.Invalid(fieldPath, value, err.Message)
+	case strings.Contains(err.Message, "duplicate value: "):
+		value := strings.TrimPrefix(err.Message, "duplicate value: ")
+		fieldErr = field.Duplicate(fieldPath, value)
+	case strings.Contains(err.Message, "invalid type: "):
+		value := strings.TrimPrefix(err.Message, "invalid type: ")
+		fieldErr = field.TypeInvalid(fieldPath, value, err.Message)
+	case strings.Contains(err.Message, "unsupported value: "):
+		value := strings.TrimPrefix(err.Message, "unsupported value: ")
+		fieldErr = field.NotSupported(fieldPath, value, nil)
+	case strings.Contains(err.Message, "not found: "):
+		value := strings.TrimPrefix(err.Message, "not found: ")
+		fieldErr = field.NotFound(fieldPath, value)
+	case strings.Contains(err.Message, "too long: "):
+		value := strings.TrimPrefix(err.Message, "too long: ")
+		fieldErr = field.TooLong(fieldPath, value, -1)
+	case strings.Contains(err.Message, "too many: "):
+		fieldErr = field.TooMany(fieldPath, -1, -1)
 	case strings.Contains(err.Message, "expected exactly one, got neither"),
 		strings.Contains(err.Message, "expected exactly one, got both"),
 		strings.Contains(err.Message, "must not update deprecated field(s)"),
@@ -49,7 +49,7 @@ var _ = Describe("Test.convertToFieldErrorToErrorList", func() {
 	})
 
 	JustBeforeEach(func() {
-		errs = convertToFieldErrorToErrorList(ctx, &err, path, ignorePathPrefix)
+		errs, _ = convertToFieldErrorToResultWithFilter(ctx, &err, path, filterForIgnorePathPrefix(ignorePathPrefix), nil)
 	})
 
 	When("err.Message contains special texts", func() {
@@ -95,6 +95,153 @@ var _ = Describe("Test.convertToFieldErrorToErrorList", func() {
 			})
 		})
 
+		When("err.Type is set by an ErrXxx constructor", func() {
+			Context("ErrDuplicateValue", func() {
+				BeforeEach(func() {
+					err = *ErrDuplicateValue("abc")
+				})
+
+				It("should return field.Duplicate error", func() {
+					Expect(errs).To(HaveLen(1))
+					Expect(errs[0]).To(Equal(field.Duplicate(fieldPath, "abc")))
+				})
+			})
+
+			Context("ErrInvalidTypeValue", func() {
+				BeforeEach(func() {
+					err = *ErrInvalidTypeValue("abc")
+				})
+
+				It("should return field.TypeInvalid error", func() {
+					Expect(errs).To(HaveLen(1))
+					Expect(errs[0]).To(Equal(field.TypeInvalid(fieldPath, "abc", err.Message)))
+				})
+			})
+
+			Context("ErrUnsupportedValue", func() {
+				BeforeEach(func() {
+					err = *ErrUnsupportedValue("abc")
+				})
+
+				It("should return field.NotSupported error", func() {
+					Expect(errs).To(HaveLen(1))
+					Expect(errs[0]).To(Equal(field.NotSupported(fieldPath, "abc", nil)))
+				})
+			})
+
+			Context("ErrValueNotFound", func() {
+				BeforeEach(func() {
+					err = *ErrValueNotFound("abc")
+				})
+
+				It("should return field.NotFound error", func() {
+					Expect(errs).To(HaveLen(1))
+					Expect(errs[0]).To(Equal(field.NotFound(fieldPath, "abc")))
+				})
+			})
+
+			Context("ErrValueTooLong", func() {
+				BeforeEach(func() {
+					err = *ErrValueTooLong("abc", 5)
+				})
+
+				It("should return field.TooLong error", func() {
+					Expect(errs).To(HaveLen(1))
+					Expect(errs[0]).To(Equal(field.TooLong(fieldPath, "abc", 5)))
+				})
+			})
+
+			Context("ErrTooManyValues", func() {
+				BeforeEach(func() {
+					err = *ErrTooManyValues(3, 2)
+				})
+
+				It("should return field.TooMany error", func() {
+					Expect(errs).To(HaveLen(1))
+					Expect(errs[0]).To(Equal(field.TooMany(fieldPath, 3, 2)))
+				})
+			})
+
+			Context("ErrInternal", func() {
+				BeforeEach(func() {
+					err = *ErrInternal(errors.New("boom"))
+				})
+
+				It("should return field.InternalError error", func() {
+					Expect(errs).To(HaveLen(1))
+					Expect(errs[0]).To(Equal(field.InternalError(fieldPath, errors.New(err.Message))))
+				})
+			})
+		})
+
+		When("err.Type is unset and err.Message matches a legacy prefix", func() {
+			Context("when 'duplicate value: '", func() {
+				BeforeEach(func() {
+					err = FieldError{Message: "duplicate value: abc"}
+				})
+
+				It("should return field.Duplicate error", func() {
+					Expect(errs).To(HaveLen(1))
+					Expect(errs[0]).To(Equal(field.Duplicate(fieldPath, "abc")))
+				})
+			})
+
+			Context("when 'invalid type: '", func() {
+				BeforeEach(func() {
+					err = FieldError{Message: "invalid type: abc"}
+				})
+
+				It("should return field.TypeInvalid error", func() {
+					Expect(errs).To(HaveLen(1))
+					Expect(errs[0]).To(Equal(field.TypeInvalid(fieldPath, "abc", err.Message)))
+				})
+			})
+
+			Context("when 'unsupported value: '", func() {
+				BeforeEach(func() {
+					err = FieldError{Message: "unsupported value: abc"}
+				})
+
+				It("should return field.NotSupported error", func() {
+					Expect(errs).To(HaveLen(1))
+					Expect(errs[0]).To(Equal(field.NotSupported(fieldPath, "abc", nil)))
+				})
+			})
+
+			Context("when 'not found: '", func() {
+				BeforeEach(func() {
+					err = FieldError{Message: "not found: abc"}
+				})
+
+				It("should return field.NotFound error", func() {
+					Expect(errs).To(HaveLen(1))
+					Expect(errs[0]).To(Equal(field.NotFound(fieldPath, "abc")))
+				})
+			})
+
+			Context("when 'too long: '", func() {
+				BeforeEach(func() {
+					err = FieldError{Message: "too long: abc"}
+				})
+
+				It("should return field.TooLong error", func() {
+					Expect(errs).To(HaveLen(1))
+					Expect(errs[0]).To(Equal(field.TooLong(fieldPath, "abc", -1)))
+				})
+			})
+
+			Context("when 'too many: '", func() {
+				BeforeEach(func() {
+					err = FieldError{Message: "too many: values"}
+				})
+
+				It("should return field.TooMany error", func() {
+					Expect(errs).To(HaveLen(1))
+					Expect(errs[0]).To(Equal(field.TooMany(fieldPath, -1, -1)))
+				})
+			})
+		})
+
 		When("err.Message contains specific texts", func() {
 			Context("when 'expected exactly one, got neither'", func() {
 				BeforeEach(func() {
@@ -338,3 +485,108 @@ var _ = Describe("Test.ConvertToFieldErrorToErrorListIgnorePathPrefix", func() {
 	})
 
 })
+
+
+var _ = Describe("Test.ConvertToFieldErrorToResult", func() {
+	var (
+		ctx                          context.Context
+		path, ignorePathPrefix       *field.Path
+		fieldError                   *FieldError
+		levelFunc                    LevelFunc
+		errorList, expectedErrorList field.ErrorList
+		warnings, expectedWarnings   []string
+	)
+
+	BeforeEach(func() {
+		ctx = context.TODO()
+		path = field.NewPath("path")
+		ignorePathPrefix = field.NewPath("")
+		levelFunc = nil
+		fieldError = &FieldError{}
+		errorList = field.ErrorList{}
+		expectedErrorList = field.ErrorList{}
+		warnings = nil
+		expectedWarnings = nil
+	})
+
+	JustBeforeEach(func() {
+		errorList, warnings = ConvertToFieldErrorToResult(ctx, fieldError, path, ignorePathPrefix, levelFunc)
+	})
+
+	When("levelFunc is nil", func() {
+		BeforeEach(func() {
+			fieldError = &FieldError{
+				Message: "missing field(s)",
+				Paths:   []string{"field1"},
+			}
+			expectedErrorList = field.ErrorList{
+				field.Required(path.Child("field1"), "missing field(s)"),
+			}
+		})
+
+		It("behaves like ConvertToFieldErrorToErrorListIgnorePathPrefix", func() {
+			Expect(errorList).To(Equal(expectedErrorList))
+			Expect(warnings).To(BeNil())
+		})
+	})
+
+	When("levelFunc classifies a sub-error as LevelWarning", func() {
+		BeforeEach(func() {
+			levelFunc = func(err FieldError) Level {
+				if err.Message == "deprecated field(s)" {
+					return LevelWarning
+				}
+				return LevelError
+			}
+			fieldError = &FieldError{
+				errors: []FieldError{
+					{
+						Message: "missing field(s)",
+						Paths:   []string{"field1"},
+					},
+					{
+						Message: "deprecated field(s)",
+						Paths:   []string{"field2"},
+					},
+				},
+			}
+			expectedErrorList = field.ErrorList{
+				field.Required(path.Child("field1"), "missing field(s)"),
+			}
+			expectedWarnings = []string{
+				field.Invalid(path.Child("field2"), "", "deprecated field(s)").Error(),
+			}
+		})
+
+		It("moves the warning-level sub-error into warnings", func() {
+			Expect(errorList).To(Equal(expectedErrorList))
+			Expect(warnings).To(Equal(expectedWarnings))
+		})
+	})
+
+	When("duplicate warnings exist", func() {
+		BeforeEach(func() {
+			levelFunc = func(FieldError) Level { return LevelDryRun }
+			fieldError = &FieldError{
+				errors: []FieldError{
+					{
+						Message: "deprecated field(s)",
+						Paths:   []string{"field1"},
+					},
+					{
+						Message: "deprecated field(s)",
+						Paths:   []string{"field1"},
+					},
+				},
+			}
+			expectedWarnings = []string{
+				field.Invalid(path.Child("field1"), "", "deprecated field(s)").Error(),
+			}
+		})
+
+		It("de-duplicates warnings the same way errors are de-duplicated", func() {
+			Expect(errorList).To(BeNil())
+			Expect(warnings).To(Equal(expectedWarnings))
+		})
+	})
+})
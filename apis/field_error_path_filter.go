@@ -0,0 +1,183 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apis
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// pathSegment is a single tokenized piece of a field.Path rendered string,
+// e.g. "spec", "tasks" or an index/key selector such as "[0]"/"[my-key]".
+type pathSegment string
+
+// pathRewrite replaces the from prefix of a matching path with to.
+type pathRewrite struct {
+	from, to []pathSegment
+}
+
+// PathFilter expresses which sub-trees of a converted error's path should be
+// kept, dropped or renamed. It tokenizes paths segment by segment (including
+// "[idx]"/"[key]" selectors) instead of doing a raw string prefix match, so
+// a prefix of "spec.tasks" matches "spec.tasks[0].name" but not a sibling
+// field like "spec.tasksExtra". The zero value keeps every path unchanged.
+type PathFilter struct {
+	includes [][]pathSegment
+	excludes [][]pathSegment
+	rewrites []pathRewrite
+}
+
+// NewPathFilter returns an empty PathFilter that keeps every path unchanged
+// until Include, Exclude or Rewrite are called on it.
+func NewPathFilter() *PathFilter {
+	return &PathFilter{}
+}
+
+// Include restricts the filter to only the sub-trees rooted at paths. Calling
+// Include more than once keeps the union of all the given sub-trees. A
+// PathFilter with no Include calls keeps everything (subject to Exclude).
+func (f *PathFilter) Include(paths ...*field.Path) *PathFilter {
+	for _, p := range paths {
+		f.includes = append(f.includes, tokenizePath(p.String()))
+	}
+	return f
+}
+
+// Exclude drops any path rooted at one of paths, even if it was kept by
+// Include.
+func (f *PathFilter) Exclude(paths ...*field.Path) *PathFilter {
+	for _, p := range paths {
+		f.excludes = append(f.excludes, tokenizePath(p.String()))
+	}
+	return f
+}
+
+// Rewrite replaces the from prefix of a matching path with to. Only the
+// first matching rewrite is applied. An empty to (e.g. field.NewPath(""))
+// strips the from prefix off.
+func (f *PathFilter) Rewrite(from, to *field.Path) *PathFilter {
+	var toSegments []pathSegment
+	if to != nil && to.String() != emptyFieldPathString {
+		toSegments = tokenizePath(to.String())
+	}
+	f.rewrites = append(f.rewrites, pathRewrite{
+		from: tokenizePath(from.String()),
+		to:   toSegments,
+	})
+	return f
+}
+
+// apply returns the filtered/rewritten path segments for pathString, and
+// whether the path survives the filter at all. A nil PathFilter always
+// keeps the path unchanged.
+func (f *PathFilter) apply(pathString string) (segments []pathSegment, keep bool) {
+	if f == nil {
+		return tokenizePath(pathString), true
+	}
+	segments = tokenizePath(pathString)
+
+	if len(f.includes) > 0 {
+		matched := false
+		for _, prefix := range f.includes {
+			if hasSegmentPrefix(segments, prefix) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return nil, false
+		}
+	}
+	for _, prefix := range f.excludes {
+		if hasSegmentPrefix(segments, prefix) {
+			return nil, false
+		}
+	}
+	for _, rw := range f.rewrites {
+		if hasSegmentPrefix(segments, rw.from) {
+			segments = append(append([]pathSegment{}, rw.to...), segments[len(rw.from):]...)
+			break
+		}
+	}
+	return segments, true
+}
+
+// hasSegmentPrefix reports whether prefix is a segment-wise prefix of
+// segments.
+func hasSegmentPrefix(segments, prefix []pathSegment) bool {
+	if len(prefix) > len(segments) {
+		return false
+	}
+	for i, seg := range prefix {
+		if segments[i] != seg {
+			return false
+		}
+	}
+	return true
+}
+
+// tokenizePath splits a field.Path-rendered string such as
+// "spec.tasks[0].name" into its dot/index segments: "spec", "tasks", "[0]",
+// "name". Index/key selectors are kept as their own segment, with brackets,
+// so they are never confused with a dotted field name.
+func tokenizePath(pathString string) []pathSegment {
+	var segments []pathSegment
+	var current strings.Builder
+
+	flush := func() {
+		if current.Len() > 0 {
+			segments = append(segments, pathSegment(current.String()))
+			current.Reset()
+		}
+	}
+
+	for i := 0; i < len(pathString); i++ {
+		switch c := pathString[i]; c {
+		case '.':
+			flush()
+		case '[':
+			flush()
+			end := strings.IndexByte(pathString[i:], ']')
+			if end < 0 {
+				current.WriteByte(c)
+				continue
+			}
+			segments = append(segments, pathSegment(pathString[i:i+end+1]))
+			i += end
+		default:
+			current.WriteByte(c)
+		}
+	}
+	flush()
+	return segments
+}
+
+// joinSegments renders segments back into a field.Path-style string,
+// attaching "[idx]"/"[key]" selectors directly to the preceding segment
+// instead of separating them with a dot.
+func joinSegments(segments []pathSegment) string {
+	var b strings.Builder
+	for i, seg := range segments {
+		s := string(seg)
+		if i > 0 && !strings.HasPrefix(s, "[") {
+			b.WriteByte('.')
+		}
+		b.WriteString(s)
+	}
+	return b.String()
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apis
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Test.tokenizePath", func() {
+	When("the path has no selectors", func() {
+		It("should split on dots", func() {
+			Expect(tokenizePath("spec.tasks.name")).To(Equal([]pathSegment{"spec", "tasks", "name"}))
+		})
+	})
+
+	When("the path has an index selector", func() {
+		It("should keep the selector as its own segment", func() {
+			Expect(tokenizePath("spec.tasks[0].name")).To(Equal([]pathSegment{"spec", "tasks", "[0]", "name"}))
+		})
+	})
+
+	When("the path has a key selector", func() {
+		It("should keep the selector as its own segment", func() {
+			Expect(tokenizePath("spec.params[my-key]")).To(Equal([]pathSegment{"spec", "params", "[my-key]"}))
+		})
+	})
+
+	When("the path is empty", func() {
+		It("should return no segments", func() {
+			Expect(tokenizePath("")).To(BeNil())
+		})
+	})
+
+	When("round-tripped through joinSegments", func() {
+		It("should reproduce the original path string", func() {
+			segments := tokenizePath("spec.tasks[0].name")
+			Expect(joinSegments(segments)).To(Equal("spec.tasks[0].name"))
+		})
+	})
+})
+
+var _ = Describe("Test.PathFilter", func() {
+	var (
+		ctx       context.Context
+		path      *field.Path
+		fieldErr  *FieldError
+		filter    *PathFilter
+		errorList field.ErrorList
+	)
+
+	BeforeEach(func() {
+		ctx = context.TODO()
+		path = field.NewPath("")
+		filter = nil
+		fieldErr = &FieldError{
+			Message: "invalid value: abc",
+			Paths:   []string{"spec", "tasks", "name"},
+		}
+	})
+
+	JustBeforeEach(func() {
+		errorList = ConvertToFieldErrorToErrorListWithFilter(ctx, fieldErr, path, filter)
+	})
+
+	When("filter is nil", func() {
+		It("should keep the path unchanged", func() {
+			Expect(errorList).To(HaveLen(1))
+			Expect(errorList[0].Field).To(Equal("spec.tasks.name"))
+		})
+	})
+
+	When("filter has a matching Include", func() {
+		BeforeEach(func() {
+			filter = NewPathFilter().Include(field.NewPath("spec", "tasks"))
+		})
+
+		It("should keep the path", func() {
+			Expect(errorList).To(HaveLen(1))
+			Expect(errorList[0].Field).To(Equal("spec.tasks.name"))
+		})
+	})
+
+	When("filter has a non-matching Include", func() {
+		BeforeEach(func() {
+			filter = NewPathFilter().Include(field.NewPath("status"))
+		})
+
+		It("should drop the path", func() {
+			Expect(errorList).To(BeNil())
+		})
+	})
+
+	When("filter has a matching Exclude", func() {
+		BeforeEach(func() {
+			filter = NewPathFilter().Exclude(field.NewPath("spec", "tasks"))
+		})
+
+		It("should drop the path", func() {
+			Expect(errorList).To(BeNil())
+		})
+	})
+
+	When("filter has a non-matching Exclude", func() {
+		BeforeEach(func() {
+			filter = NewPathFilter().Exclude(field.NewPath("status"))
+		})
+
+		It("should keep the path", func() {
+			Expect(errorList).To(HaveLen(1))
+			Expect(errorList[0].Field).To(Equal("spec.tasks.name"))
+		})
+	})
+
+	When("filter has a matching Rewrite", func() {
+		BeforeEach(func() {
+			filter = NewPathFilter().Rewrite(field.NewPath("spec", "tasks"), field.NewPath("spec", "pipelineSpec", "tasks"))
+		})
+
+		It("should rewrite the matched prefix", func() {
+			Expect(errorList).To(HaveLen(1))
+			Expect(errorList[0].Field).To(Equal("spec.pipelineSpec.tasks.name"))
+		})
+	})
+})
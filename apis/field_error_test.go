@@ -0,0 +1,155 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apis
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Test.FieldError.Also", func() {
+	When("the receiver is nil and no errs are given", func() {
+		It("returns nil", func() {
+			var fe *FieldError
+			Expect(fe.Also()).To(BeNil())
+		})
+	})
+
+	When("the receiver is nil and a single err is given", func() {
+		It("returns that err unchanged", func() {
+			var fe *FieldError
+			other := ErrMissingField("spec.name")
+			Expect(fe.Also(other)).To(Equal(other))
+		})
+	})
+
+	When("nil errs are mixed in with real ones", func() {
+		It("ignores the nils and merges the rest", func() {
+			merged := ErrMissingField("spec.name").Also(nil, ErrDisallowedFields("spec.extra"))
+			Expect(merged.Error()).To(Equal("spec.name: missing field(s)\nspec.extra: must not set the field(s)"))
+		})
+	})
+
+	When("only one non-nil error results from the merge", func() {
+		It("collapses back to a single leaf FieldError", func() {
+			merged := (&FieldError{}).Also(ErrMissingField("spec.name"))
+			Expect(merged.Error()).To(Equal("spec.name: missing field(s)"))
+			Expect(merged.Type).To(Equal(field.ErrorTypeRequired))
+		})
+	})
+
+	When("more than one error results from the merge", func() {
+		It("keeps every sibling, in order", func() {
+			merged := ErrMissingField("spec.a").Also(ErrMissingField("spec.b"), ErrMissingField("spec.c"))
+			Expect(merged.Error()).To(Equal("spec.a: missing field(s)\nspec.b: missing field(s)\nspec.c: missing field(s)"))
+		})
+	})
+})
+
+var _ = Describe("Test.FieldError.ViaField", func() {
+	When("the receiver is nil", func() {
+		It("returns nil", func() {
+			var fe *FieldError
+			Expect(fe.ViaField("spec")).To(BeNil())
+		})
+	})
+
+	When("fe is a leaf", func() {
+		It("prepends prefix to its Paths", func() {
+			fe := ErrMissingField("name").ViaField("spec")
+			Expect(fe.Error()).To(Equal("spec.name: missing field(s)"))
+			Expect(fe.Type).To(Equal(field.ErrorTypeRequired))
+		})
+	})
+
+	When("fe has merged sub-errors", func() {
+		It("prepends prefix to every sub-error's Paths", func() {
+			fe := ErrMissingField("a").Also(ErrMissingField("b")).ViaField("spec")
+			Expect(fe.Error()).To(Equal("spec.a: missing field(s)\nspec.b: missing field(s)"))
+		})
+	})
+
+	It("ViaIndex prefixes with an index selector", func() {
+		fe := ErrMissingField("name").ViaIndex(0)
+		Expect(fe.Error()).To(Equal("[0].name: missing field(s)"))
+	})
+
+	It("ViaKey prefixes with a key selector", func() {
+		fe := ErrMissingField("name").ViaKey("my-key")
+		Expect(fe.Error()).To(Equal("[my-key].name: missing field(s)"))
+	})
+
+	It("ViaFieldIndex combines ViaField and ViaIndex", func() {
+		fe := ErrMissingField("name").ViaFieldIndex("tasks", 1)
+		Expect(fe.Error()).To(Equal("tasks[1].name: missing field(s)"))
+	})
+
+	It("ViaFieldKey combines ViaField and ViaKey", func() {
+		fe := ErrMissingField("name").ViaFieldKey("params", "my-key")
+		Expect(fe.Error()).To(Equal("params[my-key].name: missing field(s)"))
+	})
+})
+
+var _ = Describe("Test.FieldError.clone", func() {
+	When("fe is nil", func() {
+		It("returns an empty FieldError instead of panicking", func() {
+			var fe *FieldError
+			Expect(fe.clone()).To(Equal(&FieldError{}))
+		})
+	})
+
+	When("fe has merged sub-errors", func() {
+		It("returns a copy whose errors slice can be mutated independently", func() {
+			fe := ErrMissingField("a").Also(ErrMissingField("b"))
+			cloned := fe.clone()
+			cloned.errors[0].Message = "changed"
+			Expect(fe.errors[0].Message).To(Equal("missing field(s)"))
+		})
+	})
+})
+
+var _ = Describe("Test.FieldError.Error", func() {
+	When("fe is nil", func() {
+		It("returns an empty string", func() {
+			var fe *FieldError
+			Expect(fe.Error()).To(Equal(""))
+		})
+	})
+
+	When("fe is a leaf with Paths", func() {
+		It("renders as \"path: message\"", func() {
+			fe := &FieldError{Message: "boom", Paths: []string{"spec", "name"}}
+			Expect(fe.Error()).To(Equal("spec.name: boom"))
+		})
+	})
+
+	When("fe is a leaf with no Paths", func() {
+		It("renders just the message", func() {
+			fe := &FieldError{Message: "boom"}
+			Expect(fe.Error()).To(Equal("boom"))
+		})
+	})
+
+	When("fe has merged sub-errors", func() {
+		It("joins each sub-error's Error() with a newline", func() {
+			fe := ErrMissingField("a").Also(ErrMissingField("b"))
+			Expect(fe.Error()).To(Equal("a: missing field(s)\nb: missing field(s)"))
+		})
+	})
+})